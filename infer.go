@@ -0,0 +1,296 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Infer walks o and returns a [Schema] describing its shape: an object
+// schema whose fields mirror the keys of o, with leaf schemas inferred from
+// the JSON type of each value and array schemas built by unifying the
+// shapes of all elements. Every field is marked [Schema.Required], since o
+// is the only sample seen, except for fields of objects inside an array that
+// some of its elements lack; use [InferAll] to relax that with more samples.
+//
+// The returned Schema can be used directly with [Schema.Decode], and its
+// [Schema.String] prints a compact JSON-Schema-ish notation of the inferred
+// shape.
+func Infer(o Object) Schema[any] {
+	return InferAll(o)
+}
+
+// InferAll is like [Infer], but derives the shape from multiple example
+// objects, unifying the shape observed for each key across all of them and
+// marking a field [Schema.Required] only if it is present in every sample.
+func InferAll(os ...Object) Schema[any] {
+	if len(os) == 0 {
+		return newInferred(desc{kind: kindAny})
+	}
+	d := inferValue(os[0])
+	for _, o := range os[1:] {
+		d = unify(d, inferValue(o))
+	}
+	return newInferred(d)
+}
+
+const (
+	kindString = "string"
+	kindInt    = "int"
+	kindFloat  = "float"
+	kindBool   = "bool"
+	kindObject = "object"
+	kindArray  = "array"
+	kindUnion  = "union"
+	kindAny    = "any"
+)
+
+// desc records the structural shape inferred for some JSON value. It backs
+// [Schema.String] and the decode logic built by [Infer] and [InferAll]; it
+// plays no role for hand-written schemas.
+type desc struct {
+	kind   string
+	fields map[string]field // kind == kindObject
+	elem   *desc            // kind == kindArray
+	union  []desc           // kind == kindUnion
+}
+
+type field struct {
+	desc     desc
+	required bool
+}
+
+// String renders d in a compact JSON-Schema-ish notation, e.g.
+// `{"a": int, "b"?: [string]}`.
+func (d desc) String() string {
+	switch d.kind {
+	case kindObject:
+		keys := make([]string, 0, len(d.fields))
+		for k := range d.fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			f := d.fields[k]
+			opt := ""
+			if !f.required {
+				opt = "?"
+			}
+			parts[i] = fmt.Sprintf("%q%s: %s", k, opt, f.desc.String())
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case kindArray:
+		return "[" + d.elem.String() + "]"
+	case kindUnion:
+		parts := make([]string, len(d.union))
+		for i, u := range d.union {
+			parts[i] = u.String()
+		}
+		return strings.Join(parts, " | ")
+	default:
+		return d.kind
+	}
+}
+
+// inferValue builds a desc describing the shape of v.
+func inferValue(v any) desc {
+	switch x := v.(type) {
+	case nil:
+		return desc{kind: kindAny}
+	case string:
+		return desc{kind: kindString}
+	case bool:
+		return desc{kind: kindBool}
+	case float64:
+		if x == float64(int64(x)) {
+			return desc{kind: kindInt}
+		}
+		return desc{kind: kindFloat}
+	case []any:
+		if len(x) == 0 {
+			return desc{kind: kindArray, elem: &desc{kind: kindAny}}
+		}
+		e := inferValue(x[0])
+		for _, y := range x[1:] {
+			e = unify(e, inferValue(y))
+		}
+		return desc{kind: kindArray, elem: &e}
+	case map[string]any, Object:
+		o, _ := AsObject(v)
+		fields := make(map[string]field, len(o))
+		for k, w := range o {
+			fields[k] = field{desc: inferValue(w), required: true}
+		}
+		return desc{kind: kindObject, fields: fields}
+	default:
+		return desc{kind: kindAny}
+	}
+}
+
+// unify merges two shapes observed for the same position (the same object
+// key across samples, or two elements of the same array) into one shape
+// that accommodates both. Int unifies with Float into Float, Any unifies
+// into whatever the other shape is, and otherwise disagreeing shapes become
+// a Union of both.
+func unify(a, b desc) desc {
+	switch {
+	case a.kind == kindAny:
+		return b
+	case b.kind == kindAny:
+		return a
+	case a.kind == kindInt && b.kind == kindFloat, a.kind == kindFloat && b.kind == kindInt:
+		return desc{kind: kindFloat}
+	case a.kind != b.kind:
+		members := append(flatten(a), flatten(b)...)
+		return desc{kind: kindUnion, union: dedup(members)}
+	}
+
+	switch a.kind {
+	case kindObject:
+		fields := make(map[string]field, len(a.fields))
+		for k, fa := range a.fields {
+			if fb, ok := b.fields[k]; ok {
+				fields[k] = field{desc: unify(fa.desc, fb.desc), required: fa.required && fb.required}
+			} else {
+				fields[k] = field{desc: fa.desc, required: false}
+			}
+		}
+		for k, fb := range b.fields {
+			if _, ok := a.fields[k]; !ok {
+				fields[k] = field{desc: fb.desc, required: false}
+			}
+		}
+		return desc{kind: kindObject, fields: fields}
+	case kindArray:
+		e := unify(*a.elem, *b.elem)
+		return desc{kind: kindArray, elem: &e}
+	case kindUnion:
+		members := append(append([]desc{}, a.union...), b.union...)
+		return desc{kind: kindUnion, union: dedup(members)}
+	default:
+		return a
+	}
+}
+
+// flatten returns the union members of d, or d itself as the sole member if
+// it isn't already a Union.
+func flatten(d desc) []desc {
+	if d.kind == kindUnion {
+		return d.union
+	}
+	return []desc{d}
+}
+
+// dedup removes shapes from ds that print the same as one already kept.
+func dedup(ds []desc) []desc {
+	seen := make(map[string]bool, len(ds))
+	out := make([]desc, 0, len(ds))
+	for _, d := range ds {
+		s := d.String()
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// newInferred builds the Schema[any] that decodes according to d.
+func newInferred(d desc) Schema[any] {
+	return Schema[any]{convert: d.convert, desc: &d}
+}
+
+// convert builds the internal Schema convert function for d, recursing into
+// nested shapes as needed.
+func (d desc) convert(v any) (any, []error, bool) {
+	switch d.kind {
+	case kindString:
+		return anyLeaf(AsString)(v)
+	case kindBool:
+		return anyLeaf(AsBool)(v)
+	case kindFloat:
+		return anyLeaf(AsFloat)(v)
+	case kindInt:
+		return anyLeaf(func(v any) (int64, bool) {
+			f, ok := AsFloat(v)
+			if !ok {
+				return 0, false
+			}
+			return AsInt(f)
+		})(v)
+	case kindObject:
+		o, ok := AsObject(v)
+		if !ok {
+			return nil, nil, false
+		}
+		keys := make([]string, 0, len(d.fields))
+		for k := range d.fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		result := make(map[string]any, len(d.fields))
+		var errs []error
+		for _, k := range keys {
+			f := d.fields[k]
+			fs := newInferred(f.desc)
+			fs.Required = f.required
+			w, es := fs.decode(k, o.Get(k))
+			errs = append(errs, es...)
+			if w != nil {
+				result[k] = w
+			}
+		}
+		return result, errs, true
+	case kindArray:
+		a, ok := AsArray(v)
+		if !ok {
+			return nil, nil, false
+		}
+		item := newInferred(*d.elem)
+		result := make([]any, len(a))
+		var errs []error
+		for i, x := range a {
+			w, es := item.decode(fmt.Sprintf("[%d]", i), ValueNode[any](x))
+			errs = append(errs, es...)
+			result[i] = w
+		}
+		return result, errs, true
+	case kindUnion:
+		for _, m := range d.union {
+			if w, es, ok := m.convert(v); ok && len(es) == 0 {
+				return w, nil, true
+			}
+		}
+		return nil, nil, false
+	default: // kindAny
+		return v, nil, true
+	}
+}
+
+// anyLeaf adapts a [Mapper] into the untyped convert signature used by
+// inferred schemas.
+func anyLeaf[T any](m Mapper[any, T]) func(any) (any, []error, bool) {
+	return func(v any) (any, []error, bool) {
+		w, ok := m(v)
+		return w, nil, ok
+	}
+}
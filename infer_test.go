@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/deep-rent/tyson"
+)
+
+func TestInfer_String(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"name":"ada","age":36,"tags":["a","b"]}`), &o)
+
+	s := tyson.Infer(o)
+
+	exp := `{"age": int, "name": string, "tags": [string]}`
+	act := s.String()
+
+	if exp != act {
+		t.Fatalf("was %q, want %q", act, exp)
+	}
+}
+
+func TestInfer_Decode(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"name":"ada","age":36}`), &o)
+
+	s := tyson.Infer(o)
+	v, errs := s.Decode(o)
+
+	if len(errs) != 0 {
+		t.Fatalf("errs was %v, want none", errs)
+	}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		t.Fatalf("was %#v", v)
+	}
+	if m["name"] != "ada" || m["age"] != int64(36) {
+		t.Fatalf("was %#v", m)
+	}
+}
+
+func TestInferAll_OptionalField(t *testing.T) {
+	var a, b tyson.Object
+	_ = json.Unmarshal([]byte(`{"name":"ada"}`), &a)
+	_ = json.Unmarshal([]byte(`{"name":"lin","nick":"lovelace"}`), &b)
+
+	s := tyson.InferAll(a, b)
+
+	exp := `{"name": string, "nick"?: string}`
+	act := s.String()
+
+	if exp != act {
+		t.Fatalf("was %q, want %q", act, exp)
+	}
+}
+
+func TestInferAll_UnionOfArrayElements(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"items":[1,"two",3]}`), &o)
+
+	s := tyson.Infer(o)
+
+	exp := `{"items": [int | string]}`
+	act := s.String()
+
+	if exp != act {
+		t.Fatalf("was %q, want %q", act, exp)
+	}
+}
+
+func TestInfer_IntFloatUnifiesToFloat(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"nums":[1,2.5]}`), &o)
+
+	s := tyson.Infer(o)
+
+	exp := `{"nums": [float]}`
+	act := s.String()
+
+	if exp != act {
+		t.Fatalf("was %q, want %q", act, exp)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A LazyObject is a view over a JSON object that, unlike [Object], does not
+// decode any of its values upfront. Values are decoded on demand as they
+// are requested through Get or one of the typed getters, and the raw bytes
+// resolved for a key path are cached, so that repeated lookups for it, or
+// for any of its descendants, do not re-scan the document from the start.
+// This makes LazyObject a better fit than [Object] for large documents
+// where only a few fields are ever read. The cache is shared across copies
+// of a LazyObject and guarded by a mutex, so a LazyObject is safe for
+// concurrent use by multiple goroutines.
+//
+// Use [OpenLazy] to create a LazyObject.
+type LazyObject struct {
+	raw   json.RawMessage
+	cache *lazyCache
+}
+
+// A lazyCache holds the index shared by every copy of a [LazyObject], guarded
+// by mu so that concurrent calls to [LazyObject.Get] do not race.
+type lazyCache struct {
+	mu    sync.Mutex
+	index map[string]json.RawMessage
+}
+
+// OpenLazy reads all of r and returns a [LazyObject] over it. It returns an
+// error if r cannot be fully read, or if its content is not a well-formed
+// JSON object.
+func OpenLazy(r io.Reader) (LazyObject, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return LazyObject{}, err
+	}
+	if !json.Valid(data) {
+		return LazyObject{}, fmt.Errorf("tyson: not well-formed JSON")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return LazyObject{}, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return LazyObject{}, fmt.Errorf("tyson: not a JSON object")
+	}
+
+	return LazyObject{
+		raw:   json.RawMessage(data),
+		cache: &lazyCache{index: make(map[string]json.RawMessage)},
+	}, nil
+}
+
+// Get follows the given hierarchy of keys to locate a target value within
+// the underlying JSON document, decoding only as much of it as necessary to
+// do so. The returned [Node] is empty if some key does not exist, or else
+// contains the target value. If no key is passed, the returned [Node]
+// contains the root of the underlying document.
+func (o LazyObject) Get(keys ...string) Node[any] {
+	raw, ok := o.resolve(keys)
+	if !ok {
+		return EmptyNode[any]()
+	}
+
+	var v any
+	if json.Unmarshal(raw, &v) != nil {
+		return EmptyNode[any]()
+	}
+	return ValueNode(v)
+}
+
+// resolve returns the raw JSON bytes addressed by keys, reusing the longest
+// already-indexed path prefix and recording every newly visited prefix in
+// o.cache.index so that later calls can resume from there. It locks
+// o.cache.mu for the duration, so concurrent calls on copies of the same
+// LazyObject are safe.
+func (o LazyObject) resolve(keys []string) (json.RawMessage, bool) {
+	o.cache.mu.Lock()
+	defer o.cache.mu.Unlock()
+
+	raw := o.raw
+	start := 0
+	for i := len(keys); i > 0; i-- {
+		if cached, ok := o.cache.index[strings.Join(keys[:i], "\x1f")]; ok {
+			raw, start = cached, i
+			break
+		}
+	}
+
+	for i := start; i < len(keys); i++ {
+		next, ok := member(raw, keys[i])
+		if !ok {
+			return nil, false
+		}
+		raw = next
+		o.cache.index[strings.Join(keys[:i+1], "\x1f")] = raw
+	}
+	return raw, true
+}
+
+// member decodes raw just far enough to extract the array element at index
+// k, or the object member named k.
+func member(raw json.RawMessage, k string) (json.RawMessage, bool) {
+	if i, err := strconv.Atoi(k); err == nil {
+		var arr []json.RawMessage
+		if json.Unmarshal(raw, &arr) == nil && i >= 0 && i < len(arr) {
+			return arr[i], true
+		}
+	}
+
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(raw, &obj) == nil {
+		if v, ok := obj[k]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+/* Basic type getters */
+
+// GetArray is the [LazyObject] equivalent of [Object.GetArray].
+func (o LazyObject) GetArray(keys ...string) Node[[]any] {
+	return Map(o.Get(keys...), AsArray)
+}
+
+// GetBool is the [LazyObject] equivalent of [Object.GetBool].
+func (o LazyObject) GetBool(keys ...string) Node[bool] {
+	return Map(o.Get(keys...), AsBool)
+}
+
+// GetFloat is the [LazyObject] equivalent of [Object.GetFloat].
+func (o LazyObject) GetFloat(keys ...string) Node[float64] {
+	return Map(o.Get(keys...), AsFloat)
+}
+
+// GetInt is the [LazyObject] equivalent of [Object.GetInt].
+func (o LazyObject) GetInt(keys ...string) Node[int64] {
+	return Map(o.GetFloat(keys...), AsInt)
+}
+
+// GetObject is the [LazyObject] equivalent of [Object.GetObject].
+func (o LazyObject) GetObject(keys ...string) Node[Object] {
+	return Map(o.Get(keys...), AsObject)
+}
+
+// GetString is the [LazyObject] equivalent of [Object.GetString].
+func (o LazyObject) GetString(keys ...string) Node[string] {
+	return Map(o.Get(keys...), AsString)
+}
+
+/* Array type getters */
+
+// GetArrays is the [LazyObject] equivalent of [Object.GetArrays].
+func (o LazyObject) GetArrays(keys ...string) Node[[][]any] {
+	return Map(o.GetArray(keys...), All(AsArray))
+}
+
+// GetBools is the [LazyObject] equivalent of [Object.GetBools].
+func (o LazyObject) GetBools(keys ...string) Node[[]bool] {
+	return Map(o.GetArray(keys...), All(AsBool))
+}
+
+// GetFloats is the [LazyObject] equivalent of [Object.GetFloats].
+func (o LazyObject) GetFloats(keys ...string) Node[[]float64] {
+	return Map(o.GetArray(keys...), All(AsFloat))
+}
+
+// GetInts is the [LazyObject] equivalent of [Object.GetInts].
+func (o LazyObject) GetInts(keys ...string) Node[[]int64] {
+	return Map(o.GetFloats(keys...), All(AsInt))
+}
+
+// GetObjects is the [LazyObject] equivalent of [Object.GetObjects].
+func (o LazyObject) GetObjects(keys ...string) Node[[]Object] {
+	return Map(o.GetArray(keys...), All(AsObject))
+}
+
+// GetStrings is the [LazyObject] equivalent of [Object.GetStrings].
+func (o LazyObject) GetStrings(keys ...string) Node[[]string] {
+	return Map(o.GetArray(keys...), All(AsString))
+}
@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson_test
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/deep-rent/tyson"
+)
+
+func TestOpenLazy_NotAnObject(t *testing.T) {
+	_, err := tyson.OpenLazy(strings.NewReader(`[1, 2, 3]`))
+	if err == nil {
+		t.Fatalf("err was nil")
+	}
+}
+
+func TestLazyObject_Get_Nested(t *testing.T) {
+	o, err := tyson.OpenLazy(strings.NewReader(`{"a":{"b":{"c":"d"}}}`))
+	if err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	exp := "d"
+	act := o.Get("a", "b", "c").Value()
+
+	if exp != act {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestLazyObject_Get_Missing(t *testing.T) {
+	o, err := tyson.OpenLazy(strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	v := o.Get("b")
+	if !v.Empty() {
+		t.Fatalf("empty was %t", v.Empty())
+	}
+}
+
+func TestLazyObject_GetString_ArrayIndex(t *testing.T) {
+	o, err := tyson.OpenLazy(strings.NewReader(`{"list":["a","b","c"]}`))
+	if err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	exp := "b"
+	act := o.GetString("list", "1").Value()
+
+	if exp != act {
+		t.Fatalf("was %q, want %q", act, exp)
+	}
+}
+
+func TestLazyObject_GetInt(t *testing.T) {
+	o, err := tyson.OpenLazy(strings.NewReader(`{"n":42}`))
+	if err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	exp := int64(42)
+	act := o.GetInt("n").Value()
+
+	if exp != act {
+		t.Fatalf("was %d, want %d", act, exp)
+	}
+}
+
+func TestLazyObject_Get_CachedPrefixReused(t *testing.T) {
+	o, err := tyson.OpenLazy(strings.NewReader(`{"a":{"b":1,"c":2}}`))
+	if err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	if o.Get("a", "b").Value() != float64(1) {
+		t.Fatalf("first lookup failed")
+	}
+	if o.Get("a", "c").Value() != float64(2) {
+		t.Fatalf("second lookup via cached prefix failed")
+	}
+}
+
+func TestLazyObject_Get_ConcurrentSafe(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(`{"a":{`)
+	for i := 0; i < 50; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(`"k` + strconv.Itoa(i) + `":` + strconv.Itoa(i))
+	}
+	b.WriteString(`}}`)
+
+	o, err := tyson.OpenLazy(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := "k" + strconv.Itoa(i)
+			exp := float64(i)
+			if act := o.Get("a", key).Value(); act != exp {
+				t.Errorf("was %#v, want %#v", act, exp)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,351 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// SetPath assigns v to the location addressed by pointer, which is parsed
+// exactly as by [Object.Path]. Intermediate objects and array elements are
+// created as needed: a segment that looks like a non-negative integer (or
+// "-", per RFC 6901) creates an array, any other segment creates an object.
+// SetPath returns an error if pointer is malformed or empty, if it addresses
+// an array, existing or newly created, at an index that is negative or
+// greater than the array's length, or if it descends into a value that is
+// neither an object nor an array.
+func (o Object) SetPath(pointer string, v any) error {
+	keys, ok := splitPath(pointer)
+	if !ok || len(keys) == 0 {
+		return fmt.Errorf("tyson: %q is not a valid pointer to a non-root value", pointer)
+	}
+	_, err := setIn(o, keys, v)
+	return err
+}
+
+// insertPath is like [Object.SetPath], but inserts rather than overwrites
+// when pointer addresses an existing array element; see [insertAt]. It
+// backs the "add", "move", and "copy" operations of [Object.ApplyPatch].
+func (o Object) insertPath(pointer string, v any) error {
+	keys, ok := splitPath(pointer)
+	if !ok || len(keys) == 0 {
+		return fmt.Errorf("tyson: %q is not a valid pointer to a non-root value", pointer)
+	}
+	_, err := insertIn(o, keys, v)
+	return err
+}
+
+// RemovePath deletes the value addressed by pointer, which is parsed
+// exactly as by [Object.Path]. It is a no-op if pointer is malformed, empty,
+// or addresses a value that does not exist.
+func (o Object) RemovePath(pointer string) {
+	keys, ok := splitPath(pointer)
+	if !ok || len(keys) == 0 {
+		return
+	}
+	_, _ = removeIn(o, keys)
+}
+
+// setIn assigns value at keys within container v, creating intermediate
+// objects or arrays as needed, and returns the (possibly new) v.
+func setIn(v any, keys []string, value any) (any, error) {
+	k := keys[0]
+	if len(keys) == 1 {
+		return assign(v, k, value)
+	}
+
+	child, ok := index(v, k)
+	if !ok {
+		child = newContainer(keys[1])
+	}
+	updated, err := setIn(child, keys[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	return assign(v, k, updated)
+}
+
+// insertIn is like setIn, but its final segment inserts into an array
+// instead of overwriting an existing element, as required by the "add"
+// operation of a JSON Patch (RFC 6902), which also backs "move" and "copy".
+func insertIn(v any, keys []string, value any) (any, error) {
+	k := keys[0]
+	if len(keys) == 1 {
+		return insertAt(v, k, value)
+	}
+
+	child, ok := index(v, k)
+	if !ok {
+		child = newContainer(keys[1])
+	}
+	updated, err := insertIn(child, keys[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	return assign(v, k, updated)
+}
+
+// insertAt sets k within container v to value, as [assign] does for an
+// object or for an array appended to via "-" or an index equal to its
+// length. Unlike assign, inserting at an existing array index shifts the
+// element currently there, and every element after it, one position to the
+// right instead of overwriting it.
+func insertAt(v any, k string, value any) (any, error) {
+	if o, ok := AsObject(v); ok {
+		o[k] = value
+		return o, nil
+	}
+	if a, ok := v.([]any); ok {
+		if k == "-" {
+			return append(a, value), nil
+		}
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i > len(a) {
+			return nil, fmt.Errorf("tyson: index %q out of range", k)
+		}
+		a = append(a, nil)
+		copy(a[i+1:], a[i:])
+		a[i] = value
+		return a, nil
+	}
+	return nil, fmt.Errorf("tyson: cannot index into %T", v)
+}
+
+// removeIn deletes the value at keys within container v, leaving v
+// untouched if any intermediate segment does not resolve, and returns the
+// (possibly new) v.
+func removeIn(v any, keys []string) (any, error) {
+	k := keys[0]
+	if len(keys) == 1 {
+		return removeAt(v, k)
+	}
+
+	child, ok := index(v, k)
+	if !ok {
+		return v, nil
+	}
+	updated, err := removeIn(child, keys[1:])
+	if err != nil {
+		return nil, err
+	}
+	return assign(v, k, updated)
+}
+
+// index looks up k — an object key or an array index — within container v.
+func index(v any, k string) (any, bool) {
+	if o, ok := AsObject(v); ok {
+		x, ok := o[k]
+		return x, ok
+	}
+	if a, ok := v.([]any); ok {
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i >= len(a) {
+			return nil, false
+		}
+		return a[i], true
+	}
+	return nil, false
+}
+
+// assign sets k within container v to value, appending to an array when k
+// is "-" or equal to its current length, and returns the (possibly new) v.
+func assign(v any, k string, value any) (any, error) {
+	if o, ok := AsObject(v); ok {
+		o[k] = value
+		return o, nil
+	}
+	if a, ok := v.([]any); ok {
+		if k == "-" {
+			return append(a, value), nil
+		}
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i > len(a) {
+			return nil, fmt.Errorf("tyson: index %q out of range", k)
+		}
+		if i == len(a) {
+			return append(a, value), nil
+		}
+		a[i] = value
+		return a, nil
+	}
+	return nil, fmt.Errorf("tyson: cannot index into %T", v)
+}
+
+// removeAt deletes k from container v; for an array it returns a new, shorter
+// slice and leaves the original backing array untouched. It is a no-op if k
+// does not resolve within v.
+func removeAt(v any, k string) (any, error) {
+	if o, ok := AsObject(v); ok {
+		delete(o, k)
+		return o, nil
+	}
+	if a, ok := v.([]any); ok {
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i >= len(a) {
+			return a, nil
+		}
+		return append(a[:i:i], a[i+1:]...), nil
+	}
+	return v, nil
+}
+
+// newContainer returns an empty []any if nextKey looks like an array index
+// or "-", or else an empty map[string]any.
+func newContainer(nextKey string) any {
+	if nextKey == "-" {
+		return []any{}
+	}
+	if i, err := strconv.Atoi(nextKey); err == nil && i >= 0 {
+		return []any{}
+	}
+	return map[string]any{}
+}
+
+// deepCopy recursively clones v so that mutating the result never affects v.
+func deepCopy(v any) any {
+	switch x := v.(type) {
+	case Object:
+		c := make(Object, len(x))
+		for k, e := range x {
+			c[k] = deepCopy(e)
+		}
+		return c
+	case map[string]any:
+		c := make(map[string]any, len(x))
+		for k, e := range x {
+			c[k] = deepCopy(e)
+		}
+		return c
+	case []any:
+		c := make([]any, len(x))
+		for i, e := range x {
+			c[i] = deepCopy(e)
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// Apply merges patch into this [Object] according to RFC 7396 (JSON Merge
+// Patch): objects are merged recursively key by key, a null value deletes
+// the corresponding key, and any other value replaces it outright.
+func (o Object) Apply(patch Object) error {
+	mergePatch(o, patch)
+	return nil
+}
+
+func mergePatch(target, patch Object) {
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+		if pv, ok := AsObject(v); ok {
+			if tv, ok := AsObject(target[k]); ok {
+				mergePatch(tv, pv)
+				target[k] = tv
+				continue
+			}
+			tv := make(Object, len(pv))
+			mergePatch(tv, pv)
+			target[k] = tv
+			continue
+		}
+		target[k] = v
+	}
+}
+
+// An Op is a single operation of a [Patch], following RFC 6902 (JSON
+// Patch). Path and From are pointers as accepted by [Object.Path]; From is
+// only used by the "move" and "copy" operations, and Value only by "add",
+// "replace", and "test".
+type Op struct {
+	Op    string
+	Path  string
+	From  string
+	Value any
+}
+
+// A Patch is a sequence of [Op] values applied atomically via
+// [Object.ApplyPatch].
+type Patch []Op
+
+// ApplyPatch applies every [Op] in p to this [Object] in order, following
+// RFC 6902. If any Op fails, every change made by the operations before it
+// is rolled back, leaving this Object exactly as it was before ApplyPatch
+// was called.
+func (o Object) ApplyPatch(p Patch) error {
+	snapshot := deepCopy(o).(Object)
+	for _, op := range p {
+		if err := o.applyOp(op); err != nil {
+			restore(o, snapshot)
+			return err
+		}
+	}
+	return nil
+}
+
+func restore(o, snapshot Object) {
+	for k := range o {
+		delete(o, k)
+	}
+	for k, v := range snapshot {
+		o[k] = v
+	}
+}
+
+func (o Object) applyOp(op Op) error {
+	switch op.Op {
+	case "add":
+		return o.insertPath(op.Path, deepCopy(op.Value))
+	case "remove":
+		if o.Path(op.Path).Empty() {
+			return fmt.Errorf("tyson: path %q does not exist", op.Path)
+		}
+		o.RemovePath(op.Path)
+		return nil
+	case "replace":
+		if o.Path(op.Path).Empty() {
+			return fmt.Errorf("tyson: path %q does not exist", op.Path)
+		}
+		return o.SetPath(op.Path, deepCopy(op.Value))
+	case "move":
+		v := o.Path(op.From)
+		if v.Empty() {
+			return fmt.Errorf("tyson: path %q does not exist", op.From)
+		}
+		o.RemovePath(op.From)
+		return o.insertPath(op.Path, v.Value())
+	case "copy":
+		v := o.Path(op.From)
+		if v.Empty() {
+			return fmt.Errorf("tyson: path %q does not exist", op.From)
+		}
+		return o.insertPath(op.Path, deepCopy(v.Value()))
+	case "test":
+		v := o.Path(op.Path)
+		if v.Empty() || !reflect.DeepEqual(v.Value(), op.Value) {
+			return fmt.Errorf("tyson: test failed at %q", op.Path)
+		}
+		return nil
+	default:
+		return fmt.Errorf("tyson: unknown op %q", op.Op)
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/deep-rent/tyson"
+)
+
+func TestObject_SetPath_CreatesIntermediates(t *testing.T) {
+	o := make(tyson.Object)
+
+	if err := o.SetPath("/a/b/0/c", "d"); err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	exp := "d"
+	act := o.Path("/a/b/0/c").Value()
+
+	if exp != act {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestObject_SetPath_Append(t *testing.T) {
+	o := make(tyson.Object)
+	o.Set("list", []any{"a"})
+
+	if err := o.SetPath("/list/-", "b"); err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	exp := []any{"a", "b"}
+	act := o.GetArray("list").Value()
+
+	if len(act) != len(exp) || act[1] != "b" {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestObject_SetPath_IndexOutOfRange(t *testing.T) {
+	o := make(tyson.Object)
+	o.Set("list", []any{"a"})
+
+	err := o.SetPath("/list/5", "b")
+	if err == nil {
+		t.Fatalf("err was nil")
+	}
+}
+
+func TestObject_RemovePath(t *testing.T) {
+	o := make(tyson.Object)
+	o.Set("a", map[string]any{"b": "c"})
+
+	o.RemovePath("/a/b")
+
+	if !o.Path("/a/b").Empty() {
+		t.Fatalf("empty was %t", o.Path("/a/b").Empty())
+	}
+}
+
+func TestObject_RemovePath_ArrayElement(t *testing.T) {
+	o := make(tyson.Object)
+	o.Set("list", []any{"a", "b", "c"})
+
+	o.RemovePath("/list/1")
+
+	exp := []any{"a", "c"}
+	act := o.GetArray("list").Value()
+
+	if len(act) != len(exp) || act[1] != "c" {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestObject_Apply_MergePatch(t *testing.T) {
+	var o, patch tyson.Object
+	_ = json.Unmarshal([]byte(`{"a":"x","b":{"c":1,"d":2},"e":3}`), &o)
+	_ = json.Unmarshal([]byte(`{"a":"y","b":{"c":null},"e":null}`), &patch)
+
+	if err := o.Apply(patch); err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	if o.GetString("a").Value() != "y" {
+		t.Fatalf("was %#v", o)
+	}
+	if !o.Get("b", "c").Empty() {
+		t.Fatalf("b.c should have been deleted")
+	}
+	if o.GetFloat("b", "d").Value() != 2 {
+		t.Fatalf("was %#v", o)
+	}
+	if o.Has("e") {
+		t.Fatalf("e should have been deleted")
+	}
+}
+
+func TestObject_ApplyPatch(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"a":1,"b":{"c":2}}`), &o)
+
+	p := tyson.Patch{
+		{Op: "add", Path: "/d", Value: float64(3)},
+		{Op: "remove", Path: "/a"},
+		{Op: "replace", Path: "/b/c", Value: float64(4)},
+		{Op: "move", From: "/d", Path: "/e"},
+		{Op: "copy", From: "/e", Path: "/f"},
+		{Op: "test", Path: "/f", Value: float64(3)},
+	}
+
+	if err := o.ApplyPatch(p); err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	if !o.Get("a").Empty() {
+		t.Fatalf("a should have been removed")
+	}
+	if o.GetFloat("b", "c").Value() != 4 {
+		t.Fatalf("was %#v", o)
+	}
+	if o.GetFloat("e").Value() != 3 || o.GetFloat("f").Value() != 3 {
+		t.Fatalf("was %#v", o)
+	}
+	if !o.Get("d").Empty() {
+		t.Fatalf("d should have been moved away")
+	}
+}
+
+func TestObject_ApplyPatch_AddIntoArray_Inserts(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"a":["x","y","z"]}`), &o)
+
+	p := tyson.Patch{
+		{Op: "add", Path: "/a/1", Value: "NEW"},
+	}
+
+	if err := o.ApplyPatch(p); err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	exp := []any{"x", "NEW", "y", "z"}
+	act := o.GetArray("a").Value()
+
+	if len(act) != len(exp) {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+	for i := range exp {
+		if act[i] != exp[i] {
+			t.Fatalf("was %#v, want %#v", act, exp)
+		}
+	}
+}
+
+func TestObject_ApplyPatch_MoveIntoArray_Inserts(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"a":["x","y","z"],"b":"NEW"}`), &o)
+
+	p := tyson.Patch{
+		{Op: "move", From: "/b", Path: "/a/1"},
+	}
+
+	if err := o.ApplyPatch(p); err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	exp := []any{"x", "NEW", "y", "z"}
+	act := o.GetArray("a").Value()
+
+	if len(act) != len(exp) {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+	for i := range exp {
+		if act[i] != exp[i] {
+			t.Fatalf("was %#v, want %#v", act, exp)
+		}
+	}
+}
+
+func TestObject_ApplyPatch_CopyIntoArray_Inserts(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"a":["x","y","z"],"b":"NEW"}`), &o)
+
+	p := tyson.Patch{
+		{Op: "copy", From: "/b", Path: "/a/1"},
+	}
+
+	if err := o.ApplyPatch(p); err != nil {
+		t.Fatalf("err was %v", err)
+	}
+
+	exp := []any{"x", "NEW", "y", "z"}
+	act := o.GetArray("a").Value()
+
+	if len(act) != len(exp) {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+	for i := range exp {
+		if act[i] != exp[i] {
+			t.Fatalf("was %#v, want %#v", act, exp)
+		}
+	}
+}
+
+func TestObject_ApplyPatch_RollbackOnFailure(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"a":1}`), &o)
+
+	p := tyson.Patch{
+		{Op: "add", Path: "/b", Value: float64(2)},
+		{Op: "remove", Path: "/does-not-exist"},
+	}
+
+	err := o.ApplyPatch(p)
+	if err == nil {
+		t.Fatalf("err was nil")
+	}
+
+	if o.Has("b") {
+		t.Fatalf("partial change was not rolled back")
+	}
+	if o.GetFloat("a").Value() != 1 {
+		t.Fatalf("was %#v", o)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson
+
+import (
+	"strconv"
+	"strings"
+)
+
+// An Array represents a JSON array fetched from an [Object] whose elements
+// have not yet been interpreted as any specific type. Unlike a plain
+// []any, it supports indexed access via [Array.Index].
+type Array []any
+
+// Index returns the element at position i. The returned [Node] is empty if i
+// is negative or exceeds the bounds of this [Array], or else contains the
+// element at that position.
+func (a Array) Index(i int) Node[any] {
+	if i < 0 || i >= len(a) {
+		return EmptyNode[any]()
+	}
+	return ValueNode(a[i])
+}
+
+// Path follows the given pointer to locate a target value within the
+// underlying JSON structure, descending into both objects and arrays. The
+// returned [Node] is empty if any referenced key does not exist or any
+// referenced index is out of bounds, or else contains the target value.
+//
+// The pointer may be given either as an RFC 6901 JSON Pointer, e.g.
+// "/a/b/0/c", or as a JSONPath expression, e.g. "$.a.b[0].c"; the notation is
+// inferred from the leading character of s. The empty string refers to this
+// [Object] itself.
+//
+// The following example fetches the nested value of "c" using a JSON
+// Pointer:
+//
+//	var o tyson.Object
+//	_ = json.Unmarshal([]byte(`{"a":{"b":[{"c":"d"}]}}`), &o)
+//	fmt.Print(o.Path("/a/b/0/c").Value()) // prints "d"
+func (o Object) Path(s string) Node[any] {
+	keys, ok := splitPath(s)
+	if !ok {
+		return EmptyNode[any]()
+	}
+
+	var v any = o
+	for _, k := range keys {
+		switch c := v.(type) {
+		case Object:
+			v = map[string]any(c)
+		case Array:
+			v = []any(c)
+		}
+		switch c := v.(type) {
+		case map[string]any:
+			if x, ok := c[k]; ok {
+				v = x
+			} else {
+				return EmptyNode[any]()
+			}
+		case []any:
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= len(c) {
+				return EmptyNode[any]()
+			}
+			v = c[i]
+		default:
+			return EmptyNode[any]()
+		}
+	}
+	return ValueNode(v)
+}
+
+// isPath returns true if k should be interpreted as a JSON Pointer or
+// JSONPath expression rather than as a literal key, i.e. if it starts with
+// "/" or "$".
+func isPath(k string) bool {
+	return strings.HasPrefix(k, "/") || strings.HasPrefix(k, "$")
+}
+
+// splitPath parses s as either a JSON Pointer or a JSONPath expression,
+// depending on its leading character, into a sequence of object keys and
+// array indices. It returns ok = false if s is malformed.
+func splitPath(s string) (keys []string, ok bool) {
+	switch {
+	case s == "":
+		return nil, true
+	case strings.HasPrefix(s, "$"):
+		return splitJSONPath(s)
+	case strings.HasPrefix(s, "/"):
+		return splitPointer(s)
+	default:
+		return nil, false
+	}
+}
+
+// splitPointer splits an RFC 6901 JSON Pointer into its reference tokens,
+// unescaping "~1" to "/" and "~0" to "~" in that order.
+func splitPointer(s string) (keys []string, ok bool) {
+	if !strings.HasPrefix(s, "/") {
+		return nil, false
+	}
+	parts := strings.Split(s[1:], "/")
+	keys = make([]string, len(parts))
+	for i, p := range parts {
+		p = strings.ReplaceAll(p, "~1", "/")
+		p = strings.ReplaceAll(p, "~0", "~")
+		keys[i] = p
+	}
+	return keys, true
+}
+
+// splitJSONPath splits a (subset of a) JSONPath expression such as
+// "$.a.b[0].c" into its member names and array indices.
+func splitJSONPath(s string) (keys []string, ok bool) {
+	s = strings.TrimPrefix(s, "$")
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, false
+			}
+			keys = append(keys, s[1:end])
+			s = s[end+1:]
+		default:
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			if end == 0 {
+				return nil, false
+			}
+			keys = append(keys, s[:end])
+			s = s[end:]
+		}
+	}
+	return keys, true
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/deep-rent/tyson"
+)
+
+func TestArray_Index(t *testing.T) {
+	a := tyson.Array{"x", "y", "z"}
+
+	exp := "y"
+	act := a.Index(1).Value()
+
+	if exp != act {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestArray_Index_OutOfBounds(t *testing.T) {
+	a := tyson.Array{"x", "y", "z"}
+
+	v := a.Index(3)
+	if !v.Empty() {
+		t.Fatalf("empty was %t", v.Empty())
+	}
+}
+
+func TestObject_Path_Root(t *testing.T) {
+	o := make(tyson.Object)
+	o.Set("foo", "bar")
+
+	exp := any(o)
+	act := o.Path("").Value()
+
+	if !reflect.DeepEqual(act, exp) {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestObject_Path_Pointer(t *testing.T) {
+	var o tyson.Object
+	mustUnmarshal(t, `{"a":{"b":[{"c":"d"}]}}`, &o)
+
+	exp := "d"
+	act := o.Path("/a/b/0/c").Value()
+
+	if exp != act {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestObject_Path_Pointer_Escaped(t *testing.T) {
+	var o tyson.Object
+	mustUnmarshal(t, `{"a/b":{"c~d":1}}`, &o)
+
+	exp := float64(1)
+	act := o.Path("/a~1b/c~0d").Value()
+
+	if exp != act {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestObject_Path_JSONPath(t *testing.T) {
+	var o tyson.Object
+	mustUnmarshal(t, `{"a":{"b":[{"c":"d"}]}}`, &o)
+
+	exp := "d"
+	act := o.Path("$.a.b[0].c").Value()
+
+	if exp != act {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestObject_Path_MissingKey(t *testing.T) {
+	var o tyson.Object
+	mustUnmarshal(t, `{"a":{"b":1}}`, &o)
+
+	v := o.Path("/a/x")
+	if !v.Empty() {
+		t.Fatalf("empty was %t", v.Empty())
+	}
+}
+
+func TestObject_Path_IndexOutOfRange(t *testing.T) {
+	var o tyson.Object
+	mustUnmarshal(t, `{"a":[1,2]}`, &o)
+
+	v := o.Path("/a/5")
+	if !v.Empty() {
+		t.Fatalf("empty was %t", v.Empty())
+	}
+}
+
+func TestObject_Get_Pointer(t *testing.T) {
+	var o tyson.Object
+	mustUnmarshal(t, `{"a":{"b":[{"c":"d"}]}}`, &o)
+
+	exp := "d"
+	act := o.Get("/a/b/0/c").Value()
+
+	if exp != act {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func TestObject_Get_LiteralKeyTakesPrecedenceOverPath(t *testing.T) {
+	var o tyson.Object
+	mustUnmarshal(t, `{"$ref":"foo"}`, &o)
+
+	exp := "foo"
+	act := o.Get("$ref").Value()
+
+	if exp != act {
+		t.Fatalf("was %#v, want %#v", act, exp)
+	}
+}
+
+func mustUnmarshal(t *testing.T, s string, o *tyson.Object) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(s), o); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// A Schema describes the expected shape of a JSON value and knows how to
+// decode it into a Go value of type T, reporting structured errors along the
+// way instead of the plain ok of a [Mapper].
+//
+// Build a Schema with one of [StringSchema], [IntSchema], [FloatSchema],
+// [BoolSchema], [ArraySchema], or [ObjectSchema], then call [Schema.Decode]
+// on an [Object].
+type Schema[T any] struct {
+	// Default is substituted for the zero value of T whenever no value
+	// is present and Required is false.
+	Default T
+	// Required marks the value as mandatory; if set and no value is
+	// present, Decode reports an error instead of silently falling back
+	// to Default.
+	Required bool
+	// Validate, if set, is applied to a successfully decoded value. A
+	// false result is reported as an error and Default is used instead.
+	Validate func(T) bool
+	// ValidateE is like Validate, but reports a reason for rejecting the
+	// value, which is included verbatim in the reported error. If both
+	// are set, ValidateE takes precedence. Build one with [WithReason]
+	// to turn a validating [Mapper] into a [MapperE] that rejects with a
+	// message.
+	ValidateE MapperE[T, T]
+
+	convert func(v any) (T, []error, bool)
+	// desc is only set for schemas produced by [Infer] or [InferAll]; it
+	// backs [Schema.String] and has no bearing on decoding.
+	desc *desc
+}
+
+// String returns a compact JSON-Schema-ish description of this Schema's
+// shape if it was produced by [Infer] or [InferAll], or else the name of T.
+func (s Schema[T]) String() string {
+	if s.desc != nil {
+		return s.desc.String()
+	}
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// StringSchema returns a [Schema] that decodes a JSON string.
+func StringSchema() Schema[string] {
+	return Schema[string]{convert: leaf(AsString)}
+}
+
+// IntSchema returns a [Schema] that decodes an integral JSON number.
+func IntSchema() Schema[int64] {
+	return Schema[int64]{convert: leaf(func(v any) (int64, bool) {
+		f, ok := AsFloat(v)
+		if !ok {
+			return 0, false
+		}
+		return AsInt(f)
+	})}
+}
+
+// FloatSchema returns a [Schema] that decodes a JSON number.
+func FloatSchema() Schema[float64] {
+	return Schema[float64]{convert: leaf(AsFloat)}
+}
+
+// BoolSchema returns a [Schema] that decodes a JSON boolean.
+func BoolSchema() Schema[bool] {
+	return Schema[bool]{convert: leaf(AsBool)}
+}
+
+// leaf adapts a [Mapper] to the internal convert signature used by Schema,
+// which never produces errors of its own beyond a failed match.
+func leaf[T any](m Mapper[any, T]) func(any) (T, []error, bool) {
+	return func(v any) (T, []error, bool) {
+		w, ok := m(v)
+		return w, nil, ok
+	}
+}
+
+// ArraySchema returns a [Schema] that decodes a JSON array whose elements
+// all conform to item. Errors produced while decoding an element are
+// reported with a "[i]" suffix identifying its index.
+func ArraySchema[T any](item Schema[T]) Schema[[]T] {
+	return Schema[[]T]{
+		convert: func(v any) ([]T, []error, bool) {
+			a, ok := AsArray(v)
+			if !ok {
+				return nil, nil, false
+			}
+
+			w := make([]T, len(a))
+			var errs []error
+			for i, x := range a {
+				y, es := item.decode(fmt.Sprintf("[%d]", i), ValueNode[any](x))
+				errs = append(errs, es...)
+				w[i] = y
+			}
+			return w, errs, true
+		},
+	}
+}
+
+// A Field binds a Schema to a path within an [Object] — a plain key, an
+// RFC 6901 JSON Pointer, or a JSONPath expression, as accepted by
+// [Object.Get] — and assigns the decoded value into a target struct. Use
+// [NewField] or [ReflectField] to create one, and pass the result to
+// [ObjectSchema].
+type Field struct {
+	decode func(path string, n Node[any]) (any, []error)
+	assign func(target reflect.Value, value any) error
+}
+
+// NewField returns a [Field] that decodes the value found at some path
+// according to schema and hands the result to set, which assigns it into
+// the target struct S passed to it by [Schema.Decode]. S must be the
+// same struct type T used in the enclosing call to ObjectSchema[T]; a
+// mismatch is reported as a decode error rather than a panic.
+func NewField[S any, T any](schema Schema[T], set func(target *S, value T)) Field {
+	return Field{
+		decode: func(path string, n Node[any]) (any, []error) {
+			return schema.decode(path, n)
+		},
+		assign: func(target reflect.Value, value any) error {
+			p, ok := target.Addr().Interface().(*S)
+			if !ok {
+				return fmt.Errorf("field was built for %T, not %T", *new(S), target.Interface())
+			}
+			w, ok := value.(T)
+			if !ok {
+				return fmt.Errorf("field was built for %T, not %T", *new(T), value)
+			}
+			set(p, w)
+			return nil
+		},
+	}
+}
+
+// ReflectField returns a [Field] equivalent to [NewField], but assigns the
+// decoded value into the exported struct field named name using reflection
+// instead of a setter closure. It reports a decode error, rather than
+// panicking, if name does not identify a settable field or its type does
+// not match the schema's T.
+func ReflectField[T any](schema Schema[T], name string) Field {
+	return Field{
+		decode: func(path string, n Node[any]) (any, []error) {
+			return schema.decode(path, n)
+		},
+		assign: func(target reflect.Value, value any) error {
+			f := target.FieldByName(name)
+			if !f.IsValid() || !f.CanSet() {
+				return fmt.Errorf("%q is not a settable field of %s", name, target.Type())
+			}
+			rv := reflect.ValueOf(value)
+			if !rv.Type().AssignableTo(f.Type()) {
+				return fmt.Errorf("cannot assign %s to field %q of type %s", rv.Type(), name, f.Type())
+			}
+			f.Set(rv)
+			return nil
+		},
+	}
+}
+
+// ObjectSchema returns a [Schema] that decodes a JSON object into T, which
+// must be a struct type, according to fields. Each key of fields is a path
+// (see [Object.Get]) identifying the value to decode; the corresponding
+// [Field] converts that value and assigns it into the resulting T.
+func ObjectSchema[T any](fields map[string]Field) Schema[T] {
+	return Schema[T]{
+		convert: func(v any) (T, []error, bool) {
+			var target T
+			o, ok := AsObject(v)
+			if !ok {
+				return target, nil, false
+			}
+
+			rv := reflect.ValueOf(&target).Elem()
+			paths := make([]string, 0, len(fields))
+			for path := range fields {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+
+			var errs []error
+			for _, path := range paths {
+				f := fields[path]
+				w, es := f.decode(path, o.Get(path))
+				errs = append(errs, es...)
+				if w != nil {
+					if err := f.assign(rv, w); err != nil {
+						errs = append(errs, fmt.Errorf("%s: %w", path, err))
+					}
+				}
+			}
+			return target, errs, true
+		},
+	}
+}
+
+// Decode applies this Schema to o, returning the fully populated value of
+// type T together with every error encountered while decoding it. A field
+// that is absent or has the wrong type falls back to its Default instead of
+// aborting the decode, so Decode always returns a usable, if possibly
+// incomplete, T.
+func (s Schema[T]) Decode(o Object) (T, []error) {
+	return s.decode("$", ValueNode[any](o))
+}
+
+func (s Schema[T]) decode(path string, n Node[any]) (T, []error) {
+	if n.Empty() {
+		if s.Required {
+			return s.Default, []error{fmt.Errorf("%s: required value is missing", path)}
+		}
+		return s.Default, nil
+	}
+
+	v, sub, ok := s.convert(n.Value())
+	if !ok {
+		return s.Default, []error{fmt.Errorf("%s: wrong type", path)}
+	}
+
+	errs := qualify(path, sub)
+	switch {
+	case s.ValidateE != nil:
+		if _, err := s.ValidateE(v); err != nil {
+			return s.Default, append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+	case s.Validate != nil && !s.Validate(v):
+		return s.Default, append(errs, fmt.Errorf("%s: failed validation", path))
+	}
+	return v, errs
+}
+
+// qualify prefixes each error in errs with base, producing paths such as
+// "$.address.city" or "$.tags[2]".
+func qualify(base string, errs []error) []error {
+	if len(errs) == 0 {
+		return nil
+	}
+	qs := make([]error, len(errs))
+	for i, e := range errs {
+		msg := e.Error()
+		if strings.HasPrefix(msg, "[") {
+			qs[i] = fmt.Errorf("%s%s", base, msg)
+		} else {
+			qs[i] = fmt.Errorf("%s.%s", base, msg)
+		}
+	}
+	return qs
+}
@@ -0,0 +1,169 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/deep-rent/tyson"
+)
+
+type person struct {
+	Name string
+	Age  int64
+	Tags []string
+}
+
+func TestSchema_Decode(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"name":"ada","age":36,"tags":["math","cs"]}`), &o)
+
+	schema := tyson.ObjectSchema[person](map[string]tyson.Field{
+		"name": tyson.NewField(tyson.StringSchema(), func(p *person, v string) { p.Name = v }),
+		"age":  tyson.NewField(tyson.IntSchema(), func(p *person, v int64) { p.Age = v }),
+		"tags": tyson.NewField(tyson.ArraySchema(tyson.StringSchema()), func(p *person, v []string) { p.Tags = v }),
+	})
+
+	v, errs := schema.Decode(o)
+	if len(errs) != 0 {
+		t.Fatalf("errs was %v, want none", errs)
+	}
+
+	if v.Name != "ada" || v.Age != 36 || len(v.Tags) != 2 {
+		t.Fatalf("was %#v", v)
+	}
+}
+
+func TestSchema_Decode_MissingRequired(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"name":"ada"}`), &o)
+
+	age := tyson.IntSchema()
+	age.Required = true
+
+	schema := tyson.ObjectSchema[person](map[string]tyson.Field{
+		"name": tyson.NewField(tyson.StringSchema(), func(p *person, v string) { p.Name = v }),
+		"age":  tyson.NewField(age, func(p *person, v int64) { p.Age = v }),
+	})
+
+	_, errs := schema.Decode(o)
+	if len(errs) != 1 {
+		t.Fatalf("errs was %v, want exactly one error", errs)
+	}
+}
+
+func TestSchema_Decode_WrongType(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"name":123}`), &o)
+
+	schema := tyson.ObjectSchema[person](map[string]tyson.Field{
+		"name": tyson.NewField(tyson.StringSchema(), func(p *person, v string) { p.Name = v }),
+	})
+
+	_, errs := schema.Decode(o)
+	if len(errs) != 1 {
+		t.Fatalf("errs was %v, want exactly one error", errs)
+	}
+}
+
+func TestSchema_Decode_Validate(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"age":-1}`), &o)
+
+	age := tyson.IntSchema()
+	age.Validate = func(v int64) bool { return v >= 0 }
+
+	schema := tyson.ObjectSchema[person](map[string]tyson.Field{
+		"age": tyson.NewField(age, func(p *person, v int64) { p.Age = v }),
+	})
+
+	_, errs := schema.Decode(o)
+	if len(errs) != 1 {
+		t.Fatalf("errs was %v, want exactly one error", errs)
+	}
+}
+
+func TestSchema_Decode_Nested(t *testing.T) {
+	type address struct{ City string }
+	type user struct {
+		Address address
+	}
+
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"address":{"city":123}}`), &o)
+
+	addressSchema := tyson.ObjectSchema[address](map[string]tyson.Field{
+		"city": tyson.NewField(tyson.StringSchema(), func(a *address, v string) { a.City = v }),
+	})
+	userSchema := tyson.ObjectSchema[user](map[string]tyson.Field{
+		"address": tyson.NewField(addressSchema, func(u *user, v address) { u.Address = v }),
+	})
+
+	_, errs := userSchema.Decode(o)
+	if len(errs) != 1 || errs[0].Error() != "$.address.city: wrong type" {
+		t.Fatalf("errs was %v", errs)
+	}
+}
+
+func TestSchema_Decode_ReflectField(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"name":"ada"}`), &o)
+
+	schema := tyson.ObjectSchema[person](map[string]tyson.Field{
+		"name": tyson.ReflectField(tyson.StringSchema(), "Name"),
+	})
+
+	v, errs := schema.Decode(o)
+	if len(errs) != 0 {
+		t.Fatalf("errs was %v, want none", errs)
+	}
+	if v.Name != "ada" {
+		t.Fatalf("was %#v", v)
+	}
+}
+
+func TestSchema_Decode_ReflectField_TypeMismatch(t *testing.T) {
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"name":123}`), &o)
+
+	schema := tyson.ObjectSchema[person](map[string]tyson.Field{
+		"name": tyson.ReflectField(tyson.IntSchema(), "Name"),
+	})
+
+	_, errs := schema.Decode(o)
+	if len(errs) != 1 {
+		t.Fatalf("errs was %v, want exactly one error", errs)
+	}
+}
+
+func TestSchema_Decode_NewField_WrongStruct(t *testing.T) {
+	type other struct{ Name string }
+
+	var o tyson.Object
+	_ = json.Unmarshal([]byte(`{"name":"ada"}`), &o)
+
+	name := tyson.NewField(tyson.StringSchema(), func(p *other, v string) { p.Name = v })
+	schema := tyson.ObjectSchema[person](map[string]tyson.Field{
+		"name": name,
+	})
+
+	_, errs := schema.Decode(o)
+	if len(errs) != 1 {
+		t.Fatalf("errs was %v, want exactly one error", errs)
+	}
+}
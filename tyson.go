@@ -31,9 +31,22 @@ func AsArray(v any) (w []any, ok bool)   { w, ok = v.([]any); return }
 func AsBool(v any) (w bool, ok bool)     { w, ok = v.(bool); return }
 func AsFloat(v any) (w float64, ok bool) { w, ok = v.(float64); return }
 func AsInt(v float64) (w int64, ok bool) { w = int64(v); return w, v == float64(w) }
-func AsObject(v any) (w Object, ok bool) { w, ok = v.(map[string]any); return }
 func AsString(v any) (w string, ok bool) { w, ok = v.(string); return }
 
+// AsObject converts v into an [Object]. It accepts both a raw
+// map[string]any, as produced by [encoding/json] when unmarshaling into
+// any, and an already-typed Object.
+func AsObject(v any) (w Object, ok bool) {
+	switch o := v.(type) {
+	case map[string]any:
+		return o, true
+	case Object:
+		return o, true
+	default:
+		return nil, false
+	}
+}
+
 // All "lifts" m to convert a slice of item type S into a slice of item type T.
 // The resulting [Mapper] indicates ok if and only if m was successfully
 // applied to each element of the input slice.
@@ -131,6 +144,13 @@ type Object map[string]any
 // not exist, or else contains the target value. If no key is passed, the
 // returned [Node] contains this [Object].
 //
+// As a special case, if exactly one key is passed, starts with "/" or "$",
+// and is not itself a literal key of this Object, it is instead interpreted
+// as a pointer and handled by [Object.Path] — this is how array elements can
+// be reached through Get and the typed getters built on top of it. A literal
+// key always takes precedence, so e.g. "$ref" is looked up directly if
+// present, even though it would otherwise read as a JSONPath expression.
+//
 // The following example fetches the nested value of "c" from the parsed JSON
 // object:
 //
@@ -142,7 +162,13 @@ func (o Object) Get(keys ...string) Node[any] {
 	case 0:
 		return ValueNode[any](o)
 	case 1:
-		return o.get(keys[0])
+		if n := o.get(keys[0]); !n.Empty() {
+			return n
+		}
+		if isPath(keys[0]) {
+			return o.Path(keys[0])
+		}
+		return EmptyNode[any]()
 	default:
 		n := ValueNode(o)
 		i := 0
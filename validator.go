@@ -0,0 +1,108 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Where returns a [Mapper] that succeeds with v unchanged if p(v) is true,
+// and fails otherwise. It turns an arbitrary predicate into a [Mapper] that
+// composes with [All], [One], and [Chain] like any other.
+func Where[S any](p func(S) bool) Mapper[S, S] {
+	return func(v S) (S, bool) {
+		if !p(v) {
+			var zero S
+			return zero, false
+		}
+		return v, true
+	}
+}
+
+// Chain returns a [Mapper] that applies m1, then m2 to its result. It fails
+// as soon as either step fails.
+func Chain[A any, B any, C any](m1 Mapper[A, B], m2 Mapper[B, C]) Mapper[A, C] {
+	return func(v A) (C, bool) {
+		w, ok := m1(v)
+		if !ok {
+			var zero C
+			return zero, false
+		}
+		return m2(w)
+	}
+}
+
+// AsIntInRange returns a [Mapper] that converts an integral JSON number,
+// succeeding only if the result lies within [min, max].
+func AsIntInRange(min, max int64) Mapper[any, int64] {
+	return Chain(Chain(AsFloat, AsInt), Where(func(v int64) bool {
+		return v >= min && v <= max
+	}))
+}
+
+// AsStringMatching returns a [Mapper] that converts a JSON string,
+// succeeding only if it matches re.
+func AsStringMatching(re *regexp.Regexp) Mapper[any, string] {
+	return Chain(AsString, Where(re.MatchString))
+}
+
+// AsEnum returns a [Mapper] that succeeds with v unchanged if it equals one
+// of allowed, and fails otherwise.
+func AsEnum[T comparable](allowed ...T) Mapper[T, T] {
+	return Where(func(v T) bool {
+		for _, a := range allowed {
+			if v == a {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// AsNonEmpty returns a [Mapper] that succeeds with v unchanged if it has at
+// least one element, and fails otherwise.
+func AsNonEmpty[T any]() Mapper[[]T, []T] {
+	return Where(func(v []T) bool { return len(v) > 0 })
+}
+
+// AsTime returns a [Mapper] that parses a string in the given layout (see
+// the [time] package) into a [time.Time].
+func AsTime(layout string) Mapper[string, time.Time] {
+	return func(v string) (time.Time, bool) {
+		t, err := time.Parse(layout, v)
+		return t, err == nil
+	}
+}
+
+// A MapperE is like a [Mapper], but reports a reason for failure instead of
+// a plain ok, so that it can feed a human-readable message into error
+// reporting such as [Schema.Decode]'s.
+type MapperE[S any, T any] func(v S) (T, error)
+
+// WithReason adapts m into a [MapperE] that reports msg as the failure
+// reason whenever m fails.
+func WithReason[S any, T any](m Mapper[S, T], msg string) MapperE[S, T] {
+	return func(v S) (T, error) {
+		if w, ok := m(v); ok {
+			return w, nil
+		}
+		var zero T
+		return zero, fmt.Errorf("%s", msg)
+	}
+}
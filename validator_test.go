@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2022 deep.rent GmbH (https://deep.rent)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tyson_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/deep-rent/tyson"
+)
+
+func TestWhere(t *testing.T) {
+	m := tyson.Where(func(v int) bool { return v > 0 })
+
+	if _, ok := m(1); !ok {
+		t.Fatalf("ok was %t, want true", ok)
+	}
+	if _, ok := m(-1); ok {
+		t.Fatalf("ok was %t, want false", ok)
+	}
+}
+
+func TestChain(t *testing.T) {
+	m := tyson.Chain(tyson.AsFloat, tyson.AsInt)
+
+	exp := int64(12)
+	act, ok := m(float64(12))
+
+	if !ok {
+		t.Fatalf("ok was %t", ok)
+	}
+	if act != exp {
+		t.Fatalf("was %d, want %d", act, exp)
+	}
+}
+
+func TestAsIntInRange(t *testing.T) {
+	m := tyson.AsIntInRange(1, 10)
+
+	if _, ok := m(float64(5)); !ok {
+		t.Fatalf("ok was %t, want true", ok)
+	}
+	if _, ok := m(float64(11)); ok {
+		t.Fatalf("ok was %t, want false", ok)
+	}
+}
+
+func TestAsStringMatching(t *testing.T) {
+	m := tyson.AsStringMatching(regexp.MustCompile(`^[a-z]+$`))
+
+	if _, ok := m("abc"); !ok {
+		t.Fatalf("ok was %t, want true", ok)
+	}
+	if _, ok := m("ABC"); ok {
+		t.Fatalf("ok was %t, want false", ok)
+	}
+}
+
+func TestAsEnum(t *testing.T) {
+	m := tyson.AsEnum("a", "b", "c")
+
+	if _, ok := m("b"); !ok {
+		t.Fatalf("ok was %t, want true", ok)
+	}
+	if _, ok := m("z"); ok {
+		t.Fatalf("ok was %t, want false", ok)
+	}
+}
+
+func TestAsNonEmpty(t *testing.T) {
+	m := tyson.AsNonEmpty[string]()
+
+	if _, ok := m([]string{"a"}); !ok {
+		t.Fatalf("ok was %t, want true", ok)
+	}
+	if _, ok := m(nil); ok {
+		t.Fatalf("ok was %t, want false", ok)
+	}
+}
+
+func TestAsTime(t *testing.T) {
+	m := tyson.AsTime(time.RFC3339)
+
+	exp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	act, ok := m("2024-01-02T03:04:05Z")
+
+	if !ok {
+		t.Fatalf("ok was %t", ok)
+	}
+	if !act.Equal(exp) {
+		t.Fatalf("was %v, want %v", act, exp)
+	}
+}
+
+func TestWithReason(t *testing.T) {
+	m := tyson.WithReason(tyson.Where(func(v int) bool { return v > 0 }), "must be positive")
+
+	_, err := m(-1)
+	if err == nil || err.Error() != "must be positive" {
+		t.Fatalf("err was %v", err)
+	}
+}
+
+func TestSchema_Decode_ValidateE(t *testing.T) {
+	age := tyson.IntSchema()
+	age.ValidateE = tyson.WithReason(tyson.Where(func(v int64) bool { return v >= 0 }), "must not be negative")
+
+	schema := tyson.ObjectSchema[person](map[string]tyson.Field{
+		"age": tyson.NewField(age, func(p *person, v int64) { p.Age = v }),
+	})
+
+	o := make(tyson.Object)
+	o.Set("age", float64(-1))
+
+	_, errs := schema.Decode(o)
+	if len(errs) != 1 || errs[0].Error() != "$.age: must not be negative" {
+		t.Fatalf("errs was %v", errs)
+	}
+}